@@ -0,0 +1,146 @@
+// Package dnd5e はD&D 5e風のルール（攻撃ボーナス、AC、ダメージダイスによる
+// d20判定）を実装するgame.GameSystemを提供する。戦闘解決自体はgame.D20Resolverを
+// そのまま利用し、このパッケージはノード処理とプレイヤーアクションのみを担う。
+package dnd5e
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gamebook/game"
+)
+
+// DnD5eSystem はD&D 5e風のルールを実装する
+type DnD5eSystem struct {
+	Roller game.DeterministicRoller // 乱数生成器。GameConfig.Seedから決定的に供給する
+}
+
+// NewDnD5eSystem は新しいDnD5eSystemインスタンスを生成
+func NewDnD5eSystem() *DnD5eSystem {
+	return &DnD5eSystem{}
+}
+
+// dnd5eState はSerialize/Deserializeでやり取りするシステム固有の状態
+type dnd5eState struct {
+	RollCounter int64 `json:"roll_counter"`
+}
+
+// Initialize はDnD5eSystemを初期化する
+func (d *DnD5eSystem) Initialize(config *game.GameConfig) error {
+	d.Roller = game.DeterministicRoller{Seed: config.Seed}
+	return nil
+}
+
+// HandleNode はノードタイプに応じて処理
+func (d *DnD5eSystem) HandleNode(gs *game.GameState, node game.Node) error {
+	switch node.Type {
+	case "story":
+		return d.handleStoryNode(gs, node)
+	case "encounter":
+		return d.handleEncounterNode(gs, node)
+	case "end":
+		return nil
+	default:
+		return fmt.Errorf("unknown node type: %s", node.Type)
+	}
+}
+
+// UpdatePlayer はRunループから渡されるアクションを処理する。対応するのは
+// heal(1d8+1回復)のみで、eat_mealを含むそれ以外のアクションはエラーを返す
+func (d *DnD5eSystem) UpdatePlayer(gs *game.GameState, action string) error {
+	switch action {
+	case "heal":
+		gs.Player.Stats["HP"] += d.Roller.Roll(8) + 1
+		gs.IO.ShowText("ショートレストでHPを回復した。")
+	default:
+		return fmt.Errorf("unknown action: %s", action)
+	}
+	return nil
+}
+
+// Serialize はRollerの消費回数を保存する
+func (d *DnD5eSystem) Serialize(gs *game.GameState) ([]byte, error) {
+	return json.Marshal(dnd5eState{RollCounter: d.Roller.Counter})
+}
+
+// Deserialize はRollerを保存時点のCounterから再開する
+func (d *DnD5eSystem) Deserialize(gs *game.GameState, data []byte) error {
+	var state dnd5eState
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &state); err != nil {
+			return fmt.Errorf("decoding dnd5e state: %v", err)
+		}
+	}
+
+	d.Roller = game.DeterministicRoller{Seed: gs.Seed, Counter: state.RollCounter}
+	return nil
+}
+
+// handleStoryNode はストーリーノードを処理
+func (d *DnD5eSystem) handleStoryNode(gs *game.GameState, node game.Node) error {
+	if len(node.Choices) == 0 {
+		gs.CurrentNodeID = "game_over"
+		return fmt.Errorf("no choices available")
+	}
+
+	options := make([]string, len(node.Choices))
+	for i, choice := range node.Choices {
+		options[i] = choice.Description
+	}
+
+	gs.CurrentNodeID = node.Choices[gs.IO.AskChoice(options)].NextNodeID
+	return nil
+}
+
+// handleEncounterNode はd20判定(1d20+ATTACK vs 敵のAC)による戦闘ノードを処理する
+func (d *DnD5eSystem) handleEncounterNode(gs *game.GameState, node game.Node) error {
+	gs.IO.ShowText("\n--- エンカウント！ ---")
+	resolver := &game.D20Resolver{DamageDie: 8}
+	ctx := game.CombatContext{Roll: d.Roller.Roll}
+	player := game.NewPlayerActor("あなた", gs.Player, "HP", "ATTACK", "AC")
+
+	for _, enemyNode := range node.Enemies {
+		enemy := game.NewEnemyActor(enemyNode)
+		for {
+			playerHPBefore := player.HP()
+			enemyHPBefore := enemy.HP()
+
+			_, effectMsgs := game.RunEncounterRound(resolver, player, enemy, ctx)
+			for _, msg := range effectMsgs {
+				gs.IO.ShowText(msg)
+			}
+
+			gs.IO.ShowCombatRound(game.CombatRoundResult{
+				AttackerName:     "あなた",
+				DefenderName:     enemyNode.Name,
+				DamageToDefender: enemyHPBefore - enemy.HP(),
+				DamageToAttacker: playerHPBefore - player.HP(),
+				AttackerHP:       player.HP(),
+				AttackerHPMax:    player.HPMax(),
+				DefenderHP:       enemy.HP(),
+				DefenderHPMax:    enemy.HPMax(),
+			})
+
+			if enemy.HP() <= 0 {
+				gs.IO.ShowText(fmt.Sprintf("%sを倒した！", enemyNode.Name))
+				break
+			}
+			if player.HP() <= 0 {
+				gs.CurrentNodeID = "game_over"
+				return fmt.Errorf("player defeated")
+			}
+		}
+	}
+
+	for _, outcome := range node.Outcomes {
+		if outcome.Condition == "combat_won" {
+			if err := gs.ApplyOutcome(outcome, d.Roller.Rand()); err != nil {
+				return err
+			}
+			gs.CurrentNodeID = outcome.NextNodeID
+			return nil
+		}
+	}
+	gs.CurrentNodeID = "game_over"
+	return fmt.Errorf("no combat_won outcome found")
+}
@@ -0,0 +1,16 @@
+//go:build ebitenui
+
+package main
+
+import (
+	"gamebook/game"
+	uiebiten "gamebook/ui/ebiten"
+)
+
+// init はrunEbitenUIを本物のui/ebiten.Runに差し替える。このファイルは
+// go build -tags ebitenuiのときだけビルドされる。
+func init() {
+	runEbitenUI = func(gs *game.GameState, loop func()) error {
+		return uiebiten.Run(gs, loop)
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !ebitenui
+
+package main
+
+import (
+	"fmt"
+
+	"gamebook/game"
+)
+
+// init はebitenuiタグ無しでビルドされたときのrunEbitenUI。ebitenはcgo経由で
+// X11/OpenGL開発ヘッダを要求するため、既定ビルドではui/ebitenを一切importせず、
+// --ui=ebitenが実際に指定されたときだけこのエラーを返す。
+func init() {
+	runEbitenUI = func(gs *game.GameState, loop func()) error {
+		return fmt.Errorf("このバイナリはebiten UI非対応でビルドされています（go build -tags ebitenuiで再ビルドしてください）")
+	}
+}
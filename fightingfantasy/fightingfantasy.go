@@ -0,0 +1,195 @@
+// Package fightingfantasy はFighting Fantasyゲームブックシリーズのルール
+// （SKILL/STAMINA/LUCKとダイスによる戦闘解決）を実装するgame.GameSystemを提供する。
+package fightingfantasy
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gamebook/game"
+)
+
+// FightingFantasySystem はFighting Fantasyのルールを実装する
+type FightingFantasySystem struct {
+	Roller game.DeterministicRoller // 乱数生成器。GameConfig.Seedから決定的に供給する
+}
+
+// NewFightingFantasySystem は新しいFightingFantasySystemインスタンスを生成
+func NewFightingFantasySystem() *FightingFantasySystem {
+	return &FightingFantasySystem{}
+}
+
+// ffState はSerialize/Deserializeでやり取りするシステム固有の状態
+type ffState struct {
+	RollCounter int64 `json:"roll_counter"`
+}
+
+// Initialize はFightingFantasySystemを初期化する
+func (ff *FightingFantasySystem) Initialize(config *game.GameConfig) error {
+	ff.Roller = game.DeterministicRoller{Seed: config.Seed}
+	return nil
+}
+
+// HandleNode はノードタイプに応じて処理
+func (ff *FightingFantasySystem) HandleNode(gs *game.GameState, node game.Node) error {
+	switch node.Type {
+	case "story":
+		return ff.handleStoryNode(gs, node)
+	case "encounter":
+		return ff.handleEncounterNode(gs, node)
+	case "random_encounter":
+		return ff.handleRandomEncounterNode(gs, node)
+	case "end":
+		return nil
+	default:
+		return fmt.Errorf("unknown node type: %s", node.Type)
+	}
+}
+
+// UpdatePlayer はRunループから渡される heal/eat_meal などのアクションを処理する
+func (ff *FightingFantasySystem) UpdatePlayer(gs *game.GameState, action string) error {
+	switch action {
+	case "heal":
+		gs.IO.ShowText("何も起こらなかった。")
+	case "eat_meal":
+		if !containsStr(gs.Player.Inventory, "provisions") {
+			return fmt.Errorf("provisionsを持っていない")
+		}
+		gs.Player.Stats["STAMINA"] += 4
+		gs.IO.ShowText("食事をとってSTAMINAを4回復した。")
+	default:
+		return fmt.Errorf("unknown action: %s", action)
+	}
+	return nil
+}
+
+// Serialize はRollerの消費回数を保存する
+func (ff *FightingFantasySystem) Serialize(gs *game.GameState) ([]byte, error) {
+	return json.Marshal(ffState{RollCounter: ff.Roller.Counter})
+}
+
+// Deserialize はRollerを保存時点のCounterから再開する
+func (ff *FightingFantasySystem) Deserialize(gs *game.GameState, data []byte) error {
+	var state ffState
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &state); err != nil {
+			return fmt.Errorf("decoding fightingfantasy state: %v", err)
+		}
+	}
+
+	ff.Roller = game.DeterministicRoller{Seed: gs.Seed, Counter: state.RollCounter}
+	return nil
+}
+
+// handleStoryNode はストーリーノードを処理
+func (ff *FightingFantasySystem) handleStoryNode(gs *game.GameState, node game.Node) error {
+	if len(node.Choices) == 0 {
+		gs.CurrentNodeID = "game_over"
+		return fmt.Errorf("no choices available")
+	}
+
+	options := make([]string, len(node.Choices))
+	for i, choice := range node.Choices {
+		options[i] = choice.Description
+	}
+
+	for {
+		choice := node.Choices[gs.IO.AskChoice(options)]
+		if item, ok := choice.Conditions["item"]; ok && !containsStr(gs.Player.Inventory, item) {
+			gs.IO.ShowText("その選択肢を選ぶための条件を満たしていません。")
+			gs.DisplayStatus()
+			continue
+		}
+
+		gs.CurrentNodeID = choice.NextNodeID
+		break
+	}
+	return nil
+}
+
+// handleEncounterNode は戦闘ノードを処理する。デフォルトではd20判定
+// (攻撃側が1d20+SKILL、敵のAC相当値以上なら命中)による戦闘解決を使う。
+func (ff *FightingFantasySystem) handleEncounterNode(gs *game.GameState, node game.Node) error {
+	gs.IO.ShowText("\n--- エンカウント！ ---")
+	resolver := ff.resolverFor(node.CombatResolver)
+	ctx := game.CombatContext{Roll: ff.Roller.Roll}
+	player := game.NewPlayerActor("あなた", gs.Player, "STAMINA", "SKILL", "SKILL")
+
+	for _, enemyNode := range node.Enemies {
+		enemy := game.NewEnemyActor(enemyNode)
+		for {
+			playerHPBefore := player.HP()
+			enemyHPBefore := enemy.HP()
+
+			_, effectMsgs := game.RunEncounterRound(resolver, player, enemy, ctx)
+			for _, msg := range effectMsgs {
+				gs.IO.ShowText(msg)
+			}
+
+			gs.IO.ShowCombatRound(game.CombatRoundResult{
+				AttackerName:     "あなた",
+				DefenderName:     enemyNode.Name,
+				DamageToDefender: enemyHPBefore - enemy.HP(),
+				DamageToAttacker: playerHPBefore - player.HP(),
+				AttackerHP:       player.HP(),
+				AttackerHPMax:    player.HPMax(),
+				DefenderHP:       enemy.HP(),
+				DefenderHPMax:    enemy.HPMax(),
+			})
+
+			if enemy.HP() <= 0 {
+				gs.IO.ShowText(fmt.Sprintf("%sを倒した！", enemyNode.Name))
+				break
+			}
+			if player.HP() <= 0 {
+				gs.CurrentNodeID = "game_over"
+				return fmt.Errorf("player defeated")
+			}
+		}
+	}
+
+	for _, outcome := range node.Outcomes {
+		if outcome.Condition == "combat_won" {
+			if err := gs.ApplyOutcome(outcome, ff.Roller.Rand()); err != nil {
+				return err
+			}
+			gs.CurrentNodeID = outcome.NextNodeID
+			return nil
+		}
+	}
+	gs.CurrentNodeID = "game_over"
+	return fmt.Errorf("no combat_won outcome found")
+}
+
+// handleRandomEncounterNode はNode.Tableによる重み付き抽選でランダムエンカウントを処理する
+func (ff *FightingFantasySystem) handleRandomEncounterNode(gs *game.GameState, node game.Node) error {
+	table := game.NewWeightedTable(node.Table)
+	next := table.Roll(ff.Roller.Rand())
+	if next == "" {
+		return fmt.Errorf("random_encounter table is empty: %s", node.ID)
+	}
+
+	gs.CurrentNodeID = next
+	return nil
+}
+
+// resolverFor はNode.CombatResolverで指定された戦闘システムを選ぶ。
+// 未指定ならd20判定を使う。
+func (ff *FightingFantasySystem) resolverFor(resolverName string) game.CombatResolver {
+	switch resolverName {
+	case "formula":
+		return &game.FormulaResolver{AttackerRatio: 1.0, CritChance: 0.1, EvasionChance: 0.05}
+	default:
+		return &game.D20Resolver{DamageDie: 6}
+	}
+}
+
+// containsStr はスライスに指定された文字列が含まれるか確認
+func containsStr(slice []string, str string) bool {
+	for _, s := range slice {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package game
+
+// PlayerActor はPlayerのStatsマップをCombatActorとして扱うためのアダプタ。
+// HP/攻撃力/防御力に使うStatsのキーはシステムごとに異なる（lonewolfならCS、
+// fightingfantasyならSKILLなど）ため、呼び出し側が指定する。
+type PlayerActor struct {
+	Player                       *Player
+	PlayerName                   string
+	HPKey, AttackKey, DefenseKey string
+}
+
+// NewPlayerActor はPlayerActorを構築する。Stats[hpKey+"MAX"]が未設定なら、
+// まだ今回の戦闘でダメージを受けていないこの時点のHPを最大値として記録する
+// （戦闘処理中にHPMax()を遅延初期化すると、その回のダメージを適用した後の
+// HPが最大値として固定されてしまうため、構築時に snapshot する）。
+func NewPlayerActor(name string, player *Player, hpKey, attackKey, defenseKey string) *PlayerActor {
+	maxKey := hpKey + "MAX"
+	if player.Stats[maxKey] == 0 {
+		player.Stats[maxKey] = player.Stats[hpKey]
+	}
+	return &PlayerActor{Player: player, PlayerName: name, HPKey: hpKey, AttackKey: attackKey, DefenseKey: defenseKey}
+}
+
+func (a *PlayerActor) Name() string { return a.PlayerName }
+func (a *PlayerActor) HP() int      { return a.Player.Stats[a.HPKey] }
+func (a *PlayerActor) SetHP(hp int) { a.Player.Stats[a.HPKey] = hp }
+func (a *PlayerActor) Attack() int  { return a.Player.Stats[a.AttackKey] }
+func (a *PlayerActor) Defense() int { return a.Player.Stats[a.DefenseKey] }
+
+// HPMax はDoT/HoTの基準となる最大HP。NewPlayerActorで記録された値を返す。
+func (a *PlayerActor) HPMax() int { return a.Player.Stats[a.HPKey+"MAX"] }
+
+func (a *PlayerActor) Effects() []Effect           { return a.Player.Effects }
+func (a *PlayerActor) SetEffects(effects []Effect) { a.Player.Effects = effects }
+
+// EnemyActor はEnemyをCombatActorとして扱うためのアダプタ。EnemyはAC相当の
+// 値を持たないシステムもあるため、Defense()でのデフォルト値を持つ。
+type EnemyActor struct {
+	Enemy *Enemy
+}
+
+// NewEnemyActor はEnemyActorを構築する。Enemy.HPMaxが未設定なら、まだ今回の
+// 戦闘でダメージを受けていないこの時点のHPを最大値として記録する（理由は
+// NewPlayerActorと同じ）。
+func NewEnemyActor(enemy *Enemy) *EnemyActor {
+	if enemy.HPMax == 0 {
+		enemy.HPMax = enemy.HP
+	}
+	return &EnemyActor{Enemy: enemy}
+}
+
+func (a *EnemyActor) Name() string { return a.Enemy.Name }
+func (a *EnemyActor) HP() int      { return a.Enemy.HP }
+func (a *EnemyActor) SetHP(hp int) { a.Enemy.HP = hp }
+func (a *EnemyActor) Attack() int  { return a.Enemy.CS }
+
+// Defense はAC相当の値を返す。Enemy.ACが設定されていなければ
+// CSから簡易的なACを見積もる。
+func (a *EnemyActor) Defense() int {
+	if a.Enemy.AC != 0 {
+		return a.Enemy.AC
+	}
+	return 10 + a.Enemy.CS/2
+}
+
+// HPMax はDoT/HoTの基準となる最大HP。NewEnemyActorで記録された値を返す。
+func (a *EnemyActor) HPMax() int { return a.Enemy.HPMax }
+
+func (a *EnemyActor) Effects() []Effect           { return a.Enemy.Effects }
+func (a *EnemyActor) SetEffects(effects []Effect) { a.Enemy.Effects = effects }
@@ -0,0 +1,86 @@
+package game
+
+// CombatActor は戦闘解決の対象となるプレイヤー/敵の最小インターフェース。
+// Playerの汎用Statsマップ、Enemyの固定フィールドなど、システムごとに異なる
+// データ表現をCombatResolverから見て同じ形に見せるためのアダプタを想定する。
+type CombatActor interface {
+	Name() string
+	HP() int
+	SetHP(hp int)
+	Attack() int  // 攻撃力に相当する値（COMBAT SKILL、攻撃ボーナスなど）
+	Defense() int // 防御力に相当する値（敵のCOMBAT SKILL、AC、DEFENSEなど）
+}
+
+// CombatContext は戦闘解決に必要な共有状態を渡す。Rollはシステム側のRandを
+// ラップした関数で、[0,n)の一様乱数を返しつつ消費回数を記録できるようにする。
+type CombatContext struct {
+	Roll func(n int) int
+}
+
+// DamageResult は1回のResolve呼び出しの結果。Mutual()がtrueのリゾルバは
+// DamageToDefender/DamageToAttackerの両方を1回のロールから算出し、falseの
+// リゾルバはDamageToDefenderのみを使う（反撃は呼び出し側がattacker/defenderを
+// 入れ替えて再度Resolveする）。
+type DamageResult struct {
+	DamageToDefender int
+	DamageToAttacker int
+	Crit             bool
+	Evaded           bool
+}
+
+// CombatResolver は戦闘1往復分のダメージ計算を抽象化する。
+// Node.CombatResolverで指定することでノードごとに異なる戦闘システムを選択できる。
+type CombatResolver interface {
+	Name() string
+	// Mutual はtrueなら1回のResolveでattacker/defender双方のダメージが
+	// 同時に決まる（Lone WolfのCRTのような相互ロール方式）ことを示す。
+	Mutual() bool
+	Resolve(attacker, defender CombatActor, ctx CombatContext) DamageResult
+}
+
+// CombatEffectActor はCombatActorとEffectTargetの両方を満たす戦闘参加者。
+// RunEncounterRoundはこれを使って継続効果と戦闘解決を1ラウンドにまとめて処理する。
+type CombatEffectActor interface {
+	CombatActor
+	EffectTarget
+}
+
+// RunEncounterRound は1ラウンド分の処理をまとめて行う: まず両者の継続効果
+// (毒や混乱など)を適用し、どちらかが倒れていればそこで終了する。
+// attacker側の行動がconfusion/stunで無効化された場合はこのラウンドの
+// 戦闘解決をスキップする。戻り値はこのラウンドで戦闘が終了したかどうかと、
+// 継続効果が生成した状況説明メッセージ（呼び出し側がgs.IO.ShowTextなどで表示する）。
+func RunEncounterRound(resolver CombatResolver, attacker, defender CombatEffectActor, ctx CombatContext) (ended bool, msgs []string) {
+	attackerMsgs, attackerSkipped := ProcessEffects(attacker, ctx.Roll)
+	defenderMsgs, _ := ProcessEffects(defender, ctx.Roll)
+	msgs = append(attackerMsgs, defenderMsgs...)
+
+	if attacker.HP() <= 0 || defender.HP() <= 0 {
+		return true, msgs
+	}
+	if attackerSkipped {
+		return false, msgs
+	}
+
+	ResolveRound(resolver, attacker, defender, ctx)
+	return attacker.HP() <= 0 || defender.HP() <= 0, msgs
+}
+
+// ResolveRound はresolverの性質に応じて1ラウンド分のダメージを両者に適用する。
+// Mutualなリゾルバは1回のResolveで双方のダメージを得るが、そうでないリゾルバは
+// attacker/defenderを入れ替えてもう一度Resolveし、防御側が生きていれば反撃させる。
+func ResolveRound(resolver CombatResolver, attacker, defender CombatActor, ctx CombatContext) {
+	result := resolver.Resolve(attacker, defender, ctx)
+	defender.SetHP(defender.HP() - result.DamageToDefender)
+
+	if resolver.Mutual() {
+		attacker.SetHP(attacker.HP() - result.DamageToAttacker)
+		return
+	}
+
+	if defender.HP() <= 0 {
+		return
+	}
+	counter := resolver.Resolve(defender, attacker, ctx)
+	attacker.SetHP(attacker.HP() - counter.DamageToDefender)
+}
@@ -0,0 +1,130 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// EffectKind はEffectの効果種別
+type EffectKind string
+
+const (
+	EffectDamageOverTime EffectKind = "damage_over_time"
+	EffectHealOverTime   EffectKind = "heal_over_time"
+	EffectConfusion      EffectKind = "confusion"
+	EffectStun           EffectKind = "stun"
+)
+
+// Effect は毒・混乱などの継続効果を表す
+type Effect struct {
+	Name     string     `json:"name"`
+	Kind     EffectKind `json:"kind"`
+	Duration int        `json:"duration"` // 残りラウンド数
+}
+
+// effectKinds はエフェクト名からKindを決めるための組み込みテーブル。
+// Node.Outcomes[].Applyの"poison:3"のような短い指定から種別を推測する。
+var effectKinds = map[string]EffectKind{
+	"poison":    EffectDamageOverTime,
+	"burn":      EffectDamageOverTime,
+	"regen":     EffectHealOverTime,
+	"confusion": EffectConfusion,
+	"stun":      EffectStun,
+}
+
+// ParseEffectSpec は"poison:3"のようなApply指定をEffectに変換する
+func ParseEffectSpec(spec string) (Effect, error) {
+	name, durationStr, hasDuration := strings.Cut(spec, ":")
+
+	duration := 1
+	if hasDuration {
+		d, err := strconv.Atoi(durationStr)
+		if err != nil {
+			return Effect{}, fmt.Errorf("invalid effect duration in %q: %v", spec, err)
+		}
+		duration = d
+	}
+
+	kind, ok := effectKinds[name]
+	if !ok {
+		return Effect{}, fmt.Errorf("unknown effect name %q (supported: poison, burn, regen, confusion, stun)", name)
+	}
+	return Effect{Name: name, Kind: kind, Duration: duration}, nil
+}
+
+// ApplyOutcome はOutcome.Applyに列挙されたエフェクトをプレイヤーに付与し、
+// Outcome.Tableがあればrから戦利品を1つ抽選してインベントリに追加する。
+func (gs *GameState) ApplyOutcome(outcome Outcome, r *rand.Rand) error {
+	for _, spec := range outcome.Apply {
+		effect, err := ParseEffectSpec(spec)
+		if err != nil {
+			return err
+		}
+		gs.Player.Effects = append(gs.Player.Effects, effect)
+	}
+
+	if len(outcome.Table) > 0 {
+		if loot := NewWeightedTable(outcome.Table).Roll(r); loot != "" {
+			gs.Player.Inventory = append(gs.Player.Inventory, loot)
+		}
+	}
+	return nil
+}
+
+// EffectTarget はステータス効果の適用対象となるプレイヤー/敵の最小インターフェース
+type EffectTarget interface {
+	HP() int
+	SetHP(hp int)
+	HPMax() int
+	Effects() []Effect
+	SetEffects(effects []Effect)
+}
+
+// ProcessEffects は1ラウンド分のステータス効果を処理する。DoT/HoTはHPMax()の
+// 2〜5%のダメージ/回復を与え、confusionは50%の確率でこのラウンドの行動を
+// 無効化し、stunは常に行動を無効化する。期限切れのエフェクトは取り除かれる。
+// 戻り値はgs.IO.ShowTextなどに渡すべき状況説明のメッセージと、このラウンドの
+// 行動を無効化すべきかどうか。呼び出し側がIOを持つため、ここではfmt.Printf等で
+// 直接標準出力に書かない。
+func ProcessEffects(target EffectTarget, roll func(n int) int) (msgs []string, skipAction bool) {
+	remaining := target.Effects()[:0]
+
+	for _, effect := range target.Effects() {
+		switch effect.Kind {
+		case EffectDamageOverTime:
+			damage := target.HPMax() * (2 + roll(4)) / 100
+			if damage < 1 {
+				damage = 1
+			}
+			target.SetHP(target.HP() - damage)
+			msgs = append(msgs, fmt.Sprintf("%sにより%dダメージを受けた。", effect.Name, damage))
+		case EffectHealOverTime:
+			heal := target.HPMax() * (2 + roll(4)) / 100
+			if heal < 1 {
+				heal = 1
+			}
+			target.SetHP(target.HP() + heal)
+			msgs = append(msgs, fmt.Sprintf("%sにより%d回復した。", effect.Name, heal))
+		case EffectConfusion:
+			if roll(2) == 0 {
+				skipAction = true
+				msgs = append(msgs, "混乱により行動できなかった！")
+			}
+		case EffectStun:
+			skipAction = true
+			msgs = append(msgs, "スタンしていて行動できない！")
+		}
+
+		effect.Duration--
+		if effect.Duration > 0 {
+			remaining = append(remaining, effect)
+		} else {
+			msgs = append(msgs, fmt.Sprintf("%sの効果が切れた。", effect.Name))
+		}
+	}
+
+	target.SetEffects(remaining)
+	return msgs, skipAction
+}
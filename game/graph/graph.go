@@ -0,0 +1,211 @@
+// Package graph はGameConfig.Nodesを有向グラフとして扱い、到達可能性や
+// 最短経路の解析、オーサリングミスの検出（gamebook lintコマンド）に使う
+// 静的解析を提供する。エッジはChoice.NextNodeIDとOutcome.NextNodeIDから
+// 作られ、Choiceが持つConditions（disciplineやitem）は経路コストとして扱う。
+package graph
+
+import "gamebook/game"
+
+const (
+	unconditionalWeight = 1
+	conditionalWeight   = 5
+)
+
+// Edge はノード間の有向辺を表す。Conditionsが空なら無条件に進める辺、
+// そうでなければ対応するdiscipline/itemを持つ場合のみ進める辺を意味する。
+type Edge struct {
+	To         string
+	Conditions map[string]string
+}
+
+// Graph はGameConfig.Nodesから構築する有向グラフ
+type Graph struct {
+	NodeIDs  []string
+	nodeSet  map[string]bool
+	nodeType map[string]string
+	edges    map[string][]Edge
+}
+
+// Build はGameConfigのNodesからGraphを構築する
+func Build(config *game.GameConfig) *Graph {
+	g := &Graph{
+		nodeSet:  make(map[string]bool),
+		nodeType: make(map[string]string),
+		edges:    make(map[string][]Edge),
+	}
+
+	for _, node := range config.Nodes {
+		g.NodeIDs = append(g.NodeIDs, node.ID)
+		g.nodeSet[node.ID] = true
+		g.nodeType[node.ID] = node.Type
+	}
+
+	for _, node := range config.Nodes {
+		for _, choice := range node.Choices {
+			g.edges[node.ID] = append(g.edges[node.ID], Edge{To: choice.NextNodeID, Conditions: choice.Conditions})
+		}
+		for _, outcome := range node.Outcomes {
+			g.edges[node.ID] = append(g.edges[node.ID], Edge{To: outcome.NextNodeID})
+		}
+	}
+
+	return g
+}
+
+// HasNode はidがグラフ上の実在するノードかどうかを返す
+func (g *Graph) HasNode(id string) bool {
+	return g.nodeSet[id]
+}
+
+// Edges はnodeIDから出る辺の一覧を返す
+func (g *Graph) Edges(nodeID string) []Edge {
+	return g.edges[nodeID]
+}
+
+// Reachable はfromから辿り着けるノードIDの集合を返す（条件の有無は無視する）
+func (g *Graph) Reachable(from string) map[string]bool {
+	visited := map[string]bool{from: true}
+	queue := []string{from}
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		for _, edge := range g.edges[id] {
+			if !g.nodeSet[edge.To] || visited[edge.To] {
+				continue
+			}
+			visited[edge.To] = true
+			queue = append(queue, edge.To)
+		}
+	}
+
+	return visited
+}
+
+// DeadEnds はtype "end"以外で、出る辺を持たないノードのIDを返す
+func (g *Graph) DeadEnds() []string {
+	var deadEnds []string
+	for _, id := range g.NodeIDs {
+		if g.nodeType[id] == "end" {
+			continue
+		}
+		if len(g.edges[id]) == 0 {
+			deadEnds = append(deadEnds, id)
+		}
+	}
+	return deadEnds
+}
+
+// ShortestPath はDijkstra法でfromからtoへの最短経路を求め、経由するノードID
+// の列を返す（到達不能ならnil）。requireItemsがfalseの場合、discipline/item
+// を要求する辺は使えない（通行不能=無限大コスト）ものとして扱う。trueの場合は
+// 通行できるが、無条件の辺より高いコストを払う経路として扱う。
+func (g *Graph) ShortestPath(from, to string, requireItems bool) []string {
+	dist := map[string]int{from: 0}
+	prev := map[string]string{}
+	visited := map[string]bool{}
+
+	pq := &priorityQueue{{id: from, dist: 0}}
+	for pq.Len() > 0 {
+		cur := pq.popMin()
+		if visited[cur.id] {
+			continue
+		}
+		visited[cur.id] = true
+		if cur.id == to {
+			break
+		}
+
+		for _, edge := range g.edges[cur.id] {
+			if !g.nodeSet[edge.To] {
+				continue
+			}
+
+			weight, passable := edgeWeight(edge, requireItems)
+			if !passable {
+				continue
+			}
+
+			next := dist[cur.id] + weight
+			if d, ok := dist[edge.To]; !ok || next < d {
+				dist[edge.To] = next
+				prev[edge.To] = cur.id
+				pq.push(pqItem{id: edge.To, dist: next})
+			}
+		}
+	}
+
+	if _, ok := dist[to]; !ok {
+		return nil
+	}
+
+	path := []string{to}
+	for path[len(path)-1] != from {
+		p, ok := prev[path[len(path)-1]]
+		if !ok {
+			return nil
+		}
+		path = append(path, p)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// edgeWeight はrequireItemsに応じた辺のコストを返す。passableがfalseなら
+// その辺は通行不能として扱う。
+func edgeWeight(edge Edge, requireItems bool) (weight int, passable bool) {
+	if len(edge.Conditions) == 0 {
+		return unconditionalWeight, true
+	}
+	if !requireItems {
+		return 0, false
+	}
+	return conditionalWeight, true
+}
+
+// TrappingCycles はプレイヤーを閉じ込める循環（サイクル内から条件なしで
+// 抜け出せる辺が1つもない強連結成分）を報告する
+func (g *Graph) TrappingCycles() [][]string {
+	var trapped [][]string
+
+	for _, scc := range g.stronglyConnectedComponents() {
+		if len(scc) < 2 && !g.hasSelfLoop(scc[0]) {
+			continue
+		}
+
+		inSCC := make(map[string]bool, len(scc))
+		for _, id := range scc {
+			inSCC[id] = true
+		}
+
+		hasUnconditionalExit := false
+		for _, id := range scc {
+			for _, edge := range g.edges[id] {
+				if !g.nodeSet[edge.To] || inSCC[edge.To] {
+					continue
+				}
+				if len(edge.Conditions) == 0 {
+					hasUnconditionalExit = true
+				}
+			}
+		}
+
+		if !hasUnconditionalExit {
+			trapped = append(trapped, scc)
+		}
+	}
+
+	return trapped
+}
+
+func (g *Graph) hasSelfLoop(id string) bool {
+	for _, edge := range g.edges[id] {
+		if edge.To == id {
+			return true
+		}
+	}
+	return false
+}
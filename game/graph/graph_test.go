@@ -0,0 +1,151 @@
+package graph
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+
+	"gamebook/game"
+)
+
+// testConfigTOML は実際のgame.toml形式に沿った小さなゲームブックを表す:
+//   - start -> branch_a -> dead_end (出る辺の無いデッドエンド)
+//   - start -> branch_b -> loop1 <-> loop2 (loop2はitem "key"でescapeへ抜けられる
+//     トラップサイクル)
+//   - unreachable はどのノードからも参照されない孤立ノード
+const testConfigTOML = `
+[[nodes]]
+id = "start"
+type = "story"
+text = "start"
+  [[nodes.choices]]
+  description = "go to branch a"
+  next_node_id = "branch_a"
+  [[nodes.choices]]
+  description = "go to branch b"
+  next_node_id = "branch_b"
+
+[[nodes]]
+id = "branch_a"
+type = "story"
+text = "branch a"
+  [[nodes.choices]]
+  description = "go to dead end"
+  next_node_id = "dead_end"
+
+[[nodes]]
+id = "dead_end"
+type = "story"
+text = "dead end"
+
+[[nodes]]
+id = "branch_b"
+type = "story"
+text = "branch b"
+  [[nodes.choices]]
+  description = "enter the loop"
+  next_node_id = "loop1"
+
+[[nodes]]
+id = "loop1"
+type = "story"
+text = "loop1"
+  [[nodes.choices]]
+  description = "go to loop2"
+  next_node_id = "loop2"
+
+[[nodes]]
+id = "loop2"
+type = "story"
+text = "loop2"
+  [[nodes.choices]]
+  description = "back to loop1"
+  next_node_id = "loop1"
+  [[nodes.choices]]
+  description = "escape with key"
+  next_node_id = "escape"
+    [nodes.choices.conditions]
+    item = "key"
+
+[[nodes]]
+id = "escape"
+type = "end"
+text = "the end"
+
+[[nodes]]
+id = "unreachable"
+type = "story"
+text = "nobody can get here"
+  [[nodes.choices]]
+  description = "go to escape"
+  next_node_id = "escape"
+`
+
+func buildTestGraph(t *testing.T) *Graph {
+	t.Helper()
+	var config game.GameConfig
+	if _, err := toml.Decode(testConfigTOML, &config); err != nil {
+		t.Fatalf("decoding test TOML: %v", err)
+	}
+	return Build(&config)
+}
+
+func TestReachable(t *testing.T) {
+	g := buildTestGraph(t)
+	reachable := g.Reachable("start")
+
+	want := []string{"start", "branch_a", "dead_end", "branch_b", "loop1", "loop2", "escape"}
+	for _, id := range want {
+		if !reachable[id] {
+			t.Errorf("expected %q to be reachable from start", id)
+		}
+	}
+	if reachable["unreachable"] {
+		t.Error("expected unreachable not to be reachable from start")
+	}
+}
+
+func TestDeadEnds(t *testing.T) {
+	g := buildTestGraph(t)
+	deadEnds := g.DeadEnds()
+
+	if len(deadEnds) != 1 || deadEnds[0] != "dead_end" {
+		t.Errorf("DeadEnds() = %v, want [dead_end]", deadEnds)
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	g := buildTestGraph(t)
+
+	if path := g.ShortestPath("start", "escape", false); path != nil {
+		t.Errorf("ShortestPath(requireItems=false) = %v, want nil (escape needs an item)", path)
+	}
+
+	path := g.ShortestPath("start", "escape", true)
+	want := []string{"start", "branch_b", "loop1", "loop2", "escape"}
+	if len(path) != len(want) {
+		t.Fatalf("ShortestPath(requireItems=true) = %v, want %v", path, want)
+	}
+	for i := range want {
+		if path[i] != want[i] {
+			t.Fatalf("ShortestPath(requireItems=true) = %v, want %v", path, want)
+		}
+	}
+}
+
+func TestTrappingCycles(t *testing.T) {
+	g := buildTestGraph(t)
+	cycles := g.TrappingCycles()
+
+	if len(cycles) != 1 {
+		t.Fatalf("TrappingCycles() returned %d cycle(s), want 1: %v", len(cycles), cycles)
+	}
+
+	got := append([]string{}, cycles[0]...)
+	sort.Strings(got)
+	want := []string{"loop1", "loop2"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("TrappingCycles()[0] = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,34 @@
+package graph
+
+// pqItem はpriorityQueueの1要素
+type pqItem struct {
+	id   string
+	dist int
+}
+
+// priorityQueue はShortestPathのDijkstra法で使う優先度付きキュー。ノード数が
+// ゲームブック1冊分程度の規模であることを踏まえ、container/heapは使わず
+// 単純な線形探索で最小要素を取り出す。
+type priorityQueue []pqItem
+
+func (pq *priorityQueue) push(item pqItem) {
+	*pq = append(*pq, item)
+}
+
+func (pq *priorityQueue) popMin() pqItem {
+	items := *pq
+	minIdx := 0
+	for i, item := range items {
+		if item.dist < items[minIdx].dist {
+			minIdx = i
+		}
+	}
+	min := items[minIdx]
+	items[minIdx] = items[len(items)-1]
+	*pq = items[:len(items)-1]
+	return min
+}
+
+func (pq *priorityQueue) Len() int {
+	return len(*pq)
+}
@@ -0,0 +1,71 @@
+package graph
+
+// tarjanState はTarjanの強連結成分分解アルゴリズムの作業状態を保持する
+type tarjanState struct {
+	index   int
+	indices map[string]int
+	lowlink map[string]int
+	onStack map[string]bool
+	stack   []string
+	sccs    [][]string
+}
+
+// stronglyConnectedComponents はTarjan法でグラフの強連結成分を列挙する
+func (g *Graph) stronglyConnectedComponents() [][]string {
+	st := &tarjanState{
+		indices: make(map[string]int),
+		lowlink: make(map[string]int),
+		onStack: make(map[string]bool),
+	}
+
+	for _, id := range g.NodeIDs {
+		if _, visited := st.indices[id]; !visited {
+			g.strongConnect(id, st)
+		}
+	}
+
+	return st.sccs
+}
+
+func (g *Graph) strongConnect(v string, st *tarjanState) {
+	st.indices[v] = st.index
+	st.lowlink[v] = st.index
+	st.index++
+	st.stack = append(st.stack, v)
+	st.onStack[v] = true
+
+	for _, edge := range g.edges[v] {
+		w := edge.To
+		if !g.nodeSet[w] {
+			continue
+		}
+
+		if _, visited := st.indices[w]; !visited {
+			g.strongConnect(w, st)
+			if st.lowlink[w] < st.lowlink[v] {
+				st.lowlink[v] = st.lowlink[w]
+			}
+		} else if st.onStack[w] {
+			if st.indices[w] < st.lowlink[v] {
+				st.lowlink[v] = st.indices[w]
+			}
+		}
+	}
+
+	if st.lowlink[v] != st.indices[v] {
+		return
+	}
+
+	var scc []string
+	for {
+		n := len(st.stack) - 1
+		w := st.stack[n]
+		st.stack = st.stack[:n]
+		st.onStack[w] = false
+		scc = append(scc, w)
+		if w == v {
+			break
+		}
+	}
+	st.sccs = append(st.sccs, scc)
+}
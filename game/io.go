@@ -0,0 +1,33 @@
+package game
+
+// CombatRoundResult は1ラウンド分の戦闘結果をUIに表示するための情報をまとめたもの
+type CombatRoundResult struct {
+	AttackerName string
+	DefenderName string
+
+	DamageToDefender int
+	DamageToAttacker int
+
+	AttackerHP    int
+	AttackerHPMax int
+	DefenderHP    int
+	DefenderHPMax int
+}
+
+// IO はプレイヤーとの入出力を抽象化する。LoneWolfSystemやFightingFantasySystemは
+// fmt.Println/bufio.Readerに直接触れず、GameState.IO経由でやり取りすることで、
+// ターミナルとEbitenウィンドウなど複数のフロントエンドを差し替えられるようにする。
+type IO interface {
+	// ShowText はノードの本文や状況説明など、地の文を表示する
+	ShowText(text string)
+
+	// AskChoice はoptionsを提示し、選ばれたインデックス(0始まり)を返す
+	AskChoice(options []string) int
+
+	// ReadLine はpromptを表示し、自由入力された文字列を返す
+	// (Run()のアクション入力に使う)
+	ReadLine(prompt string) string
+
+	// ShowCombatRound は1ラウンド分の戦闘結果を表示する
+	ShowCombatRound(result CombatRoundResult)
+}
@@ -0,0 +1,63 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TerminalIO は標準入出力を使ったIO実装。これまでmain.goや各システムが
+// 直接行っていたfmt.Println/bufio.Readerでのやり取りをまとめたもの。
+type TerminalIO struct {
+	Reader *bufio.Reader
+}
+
+// NewTerminalIO は新しいTerminalIOインスタンスを生成
+func NewTerminalIO(reader *bufio.Reader) *TerminalIO {
+	return &TerminalIO{Reader: reader}
+}
+
+// ShowText はテキストを1行出力する
+func (t *TerminalIO) ShowText(text string) {
+	fmt.Println(text)
+}
+
+// AskChoice は選択肢を番号付きで表示し、有効な番号が入力されるまで尋ね続ける
+func (t *TerminalIO) AskChoice(options []string) int {
+	fmt.Println("\n選択肢:")
+	for i, opt := range options {
+		fmt.Printf("%d. %s\n", i+1, opt)
+	}
+
+	for {
+		fmt.Print("選択してください (番号): ")
+		input, _ := t.Reader.ReadString('\n')
+		input = strings.TrimSpace(input)
+		n, err := strconv.Atoi(input)
+		if err == nil && n >= 1 && n <= len(options) {
+			return n - 1
+		}
+		fmt.Println("無効な入力です。")
+	}
+}
+
+// ReadLine はpromptを表示し、入力された1行をそのまま返す
+func (t *TerminalIO) ReadLine(prompt string) string {
+	fmt.Print(prompt)
+	line, _ := t.Reader.ReadString('\n')
+	return strings.TrimSpace(line)
+}
+
+// ShowCombatRound は1ラウンド分の戦闘結果を表示する
+func (t *TerminalIO) ShowCombatRound(result CombatRoundResult) {
+	fmt.Printf("\n%s (HP:%d/%d)\n", result.AttackerName, result.AttackerHP, result.AttackerHPMax)
+	fmt.Printf("%s (HP:%d/%d)\n", result.DefenderName, result.DefenderHP, result.DefenderHPMax)
+
+	if result.DamageToDefender > 0 {
+		fmt.Printf("%sは%sに%dダメージを与えた！\n", result.AttackerName, result.DefenderName, result.DamageToDefender)
+	}
+	if result.DamageToAttacker > 0 {
+		fmt.Printf("%sは%dダメージを受けた！\n", result.AttackerName, result.DamageToAttacker)
+	}
+}
@@ -0,0 +1,27 @@
+package game
+
+import "math/rand"
+
+// DeterministicRoller は [0,n) の一様乱数を、Seedと消費済みロール数(Counter)だけから
+// 決定的に供給する。ロールのたびに新しいrand.Randを(Seed, Counter)から起こすため、
+// 単一のrand.Randに対して毎回異なる大きさのIntn(n)を呼び続けるのと違い、
+// 「何回目のロールか」さえ一致していればd20とd6が混在していても再現できる。
+type DeterministicRoller struct {
+	Seed    int64
+	Counter int64
+}
+
+// Roll は[0,n)の乱数を1つ引き、Counterを1つ進める。
+func (d *DeterministicRoller) Roll(n int) int {
+	r := rand.New(rand.NewSource(d.Seed ^ d.Counter))
+	d.Counter++
+	return r.Intn(n)
+}
+
+// Rand は現在のCounterから*rand.Randを1つ起こし、Counterを1つ進める。
+// WeightedTable.Rollのように既存の*rand.Randを受け取るAPIに橋渡しするためのもの。
+func (d *DeterministicRoller) Rand() *rand.Rand {
+	r := rand.New(rand.NewSource(d.Seed ^ d.Counter))
+	d.Counter++
+	return r
+}
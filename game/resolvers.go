@@ -0,0 +1,62 @@
+package game
+
+// D20Resolver はD&D風のd20判定による戦闘解決。攻撃側が1d20+Attack()を振り、
+// 防御側のDefense()（AC相当）以上なら命中、DamageDie面ダイスでダメージを与える。
+// fightingfantasyとdnd5eの両方から利用される共通実装。
+type D20Resolver struct {
+	DamageDie int // 命中時のダメージダイス（例: 8 なら1d8）
+}
+
+func (r *D20Resolver) Name() string { return "d20" }
+func (r *D20Resolver) Mutual() bool { return false }
+
+func (r *D20Resolver) Resolve(attacker, defender CombatActor, ctx CombatContext) DamageResult {
+	roll := ctx.Roll(20) + 1
+	crit := roll == 20
+	total := roll + attacker.Attack()
+
+	if total < defender.Defense() && !crit {
+		return DamageResult{Evaded: true}
+	}
+
+	damage := ctx.Roll(r.DamageDie) + 1
+	if crit {
+		damage += ctx.Roll(r.DamageDie) + 1
+	}
+	return DamageResult{DamageToDefender: damage, Crit: crit}
+}
+
+// FormulaResolver はクラシックなMMOのバトル計算式を模した戦闘解決。
+// damage = max(1, Attack()*AttackerRatio - Defense())を基本とし、
+// クリティカルと回避の判定を挟む。
+type FormulaResolver struct {
+	AttackerRatio  float64 // 攻撃力に掛ける係数
+	CritChance     float64 // 0.0-1.0
+	CritMultiplier float64 // クリティカル時のダメージ倍率
+	EvasionChance  float64 // 0.0-1.0
+}
+
+func (r *FormulaResolver) Name() string { return "formula" }
+func (r *FormulaResolver) Mutual() bool { return false }
+
+func (r *FormulaResolver) Resolve(attacker, defender CombatActor, ctx CombatContext) DamageResult {
+	if r.EvasionChance > 0 && ctx.Roll(1000) < int(r.EvasionChance*1000) {
+		return DamageResult{Evaded: true}
+	}
+
+	damage := int(float64(attacker.Attack())*r.AttackerRatio) - defender.Defense()
+	if damage < 1 {
+		damage = 1
+	}
+
+	crit := r.CritChance > 0 && ctx.Roll(1000) < int(r.CritChance*1000)
+	if crit {
+		multiplier := r.CritMultiplier
+		if multiplier == 0 {
+			multiplier = 2
+		}
+		damage = int(float64(damage) * multiplier)
+	}
+
+	return DamageResult{DamageToDefender: damage, Crit: crit}
+}
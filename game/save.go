@@ -0,0 +1,73 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// saveFile はセーブデータのファイル上の表現
+type saveFile struct {
+	Seed          int64           `json:"seed"`
+	CurrentNodeID string          `json:"current_node_id"`
+	Player        *Player         `json:"player"`
+	SystemState   json.RawMessage `json:"system_state"`
+}
+
+// SaveManager はGameStateのスナップショットをファイルに保存/復元する
+type SaveManager struct {
+	Path string
+}
+
+// NewSaveManager は指定したパスに対するSaveManagerを生成する
+func NewSaveManager(path string) *SaveManager {
+	return &SaveManager{Path: path}
+}
+
+// Save はgsの現在の状態をJSONファイルに書き出す
+func (sm *SaveManager) Save(gs *GameState) error {
+	systemState, err := gs.System.Serialize(gs)
+	if err != nil {
+		return fmt.Errorf("serializing system state: %w", err)
+	}
+
+	data := saveFile{
+		Seed:          gs.Seed,
+		CurrentNodeID: gs.CurrentNodeID,
+		Player:        gs.Player,
+		SystemState:   systemState,
+	}
+
+	encoded, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding save data: %w", err)
+	}
+
+	if err := os.WriteFile(sm.Path, encoded, 0644); err != nil {
+		return fmt.Errorf("writing save file: %w", err)
+	}
+	return nil
+}
+
+// Load はファイルからgsへ状態を復元する。Reader/Nodes/Configなど
+// セッション固有のフィールドはそのまま維持される。
+func (sm *SaveManager) Load(gs *GameState) error {
+	raw, err := os.ReadFile(sm.Path)
+	if err != nil {
+		return fmt.Errorf("reading save file: %w", err)
+	}
+
+	var data saveFile
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return fmt.Errorf("decoding save data: %w", err)
+	}
+
+	gs.Seed = data.Seed
+	gs.CurrentNodeID = data.CurrentNodeID
+	gs.Player = data.Player
+
+	if err := gs.System.Deserialize(gs, data.SystemState); err != nil {
+		return fmt.Errorf("deserializing system state: %w", err)
+	}
+	return nil
+}
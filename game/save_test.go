@@ -0,0 +1,90 @@
+package game
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+// fakeSystemState はfakeSystemがSerialize/Deserializeでやり取りする内部状態。
+// lonewolf.lonewolfStateのような、システム固有状態の最小の例として使う。
+type fakeSystemState struct {
+	RollCounter int `json:"roll_counter"`
+}
+
+// fakeSystem はSaveManagerのテストに使う最小のGameSystem実装
+type fakeSystem struct {
+	RollCounter int
+}
+
+func (f *fakeSystem) Initialize(config *GameConfig) error             { return nil }
+func (f *fakeSystem) HandleNode(gs *GameState, node Node) error       { return nil }
+func (f *fakeSystem) UpdatePlayer(gs *GameState, action string) error { return nil }
+
+func (f *fakeSystem) Serialize(gs *GameState) ([]byte, error) {
+	return json.Marshal(fakeSystemState{RollCounter: f.RollCounter})
+}
+
+func (f *fakeSystem) Deserialize(gs *GameState, data []byte) error {
+	var state fakeSystemState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	f.RollCounter = state.RollCounter
+	return nil
+}
+
+// TestSaveManagerRoundTrip はSave→(状態を書き換える)→Loadの一往復が、
+// Player/CurrentNodeID/Seedに加えてSerialize/Deserialize経由のシステム
+// 固有状態(fakeSystem.RollCounter)まで正しく復元することを確認する。
+func TestSaveManagerRoundTrip(t *testing.T) {
+	system := &fakeSystem{RollCounter: 7}
+	gs := &GameState{
+		Seed:          42,
+		CurrentNodeID: "node_a",
+		Player: &Player{
+			Stats:      map[string]int{"HP": 17, "HPMAX": 20},
+			Attributes: map[string]bool{"has_sword": true},
+			Inventory:  []string{"torch", "rope"},
+			Equipment:  map[string]string{"weapon": "sword"},
+		},
+		System: system,
+	}
+
+	sm := NewSaveManager(filepath.Join(t.TempDir(), "save.json"))
+	if err := sm.Save(gs); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	// Save後にgsとsystemを書き換え、Loadが保存時点の値に戻すことを確認する。
+	gs.Seed = 0
+	gs.CurrentNodeID = "node_b"
+	gs.Player = &Player{Stats: map[string]int{"HP": 1}}
+	system.RollCounter = 0
+
+	if err := sm.Load(gs); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if gs.Seed != 42 {
+		t.Errorf("Seed = %d, want 42", gs.Seed)
+	}
+	if gs.CurrentNodeID != "node_a" {
+		t.Errorf("CurrentNodeID = %q, want node_a", gs.CurrentNodeID)
+	}
+	if gs.Player.Stats["HP"] != 17 || gs.Player.Stats["HPMAX"] != 20 {
+		t.Errorf("Player.Stats = %v, want HP=17 HPMAX=20", gs.Player.Stats)
+	}
+	if !gs.Player.Attributes["has_sword"] {
+		t.Error("Player.Attributes[has_sword] = false, want true")
+	}
+	if len(gs.Player.Inventory) != 2 || gs.Player.Inventory[0] != "torch" || gs.Player.Inventory[1] != "rope" {
+		t.Errorf("Player.Inventory = %v, want [torch rope]", gs.Player.Inventory)
+	}
+	if gs.Player.Equipment["weapon"] != "sword" {
+		t.Errorf("Player.Equipment[weapon] = %q, want sword", gs.Player.Equipment["weapon"])
+	}
+	if system.RollCounter != 7 {
+		t.Errorf("system.RollCounter = %d, want 7 (Deserialize should restore system state)", system.RollCounter)
+	}
+}
@@ -0,0 +1,72 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NewPlayerFromConfig はTOMLのplayerテーブルからPlayerを構築する
+func NewPlayerFromConfig(config map[string]interface{}) *Player {
+	player := &Player{
+		Stats:      make(map[string]int),
+		Attributes: make(map[string]bool),
+		Inventory:  []string{},
+		Equipment:  make(map[string]string),
+	}
+
+	if stats, ok := config["stats"].(map[string]interface{}); ok {
+		for k, v := range stats {
+			if val, ok := v.(int64); ok {
+				player.Stats[k] = int(val)
+			}
+		}
+	}
+	if attributes, ok := config["attributes"].(map[string]interface{}); ok {
+		for k, v := range attributes {
+			if val, ok := v.(bool); ok {
+				player.Attributes[k] = val
+			}
+		}
+	}
+	if inventory, ok := config["inventory"].([]interface{}); ok {
+		for _, item := range inventory {
+			if str, ok := item.(string); ok {
+				player.Inventory = append(player.Inventory, str)
+			}
+		}
+	}
+	if equipment, ok := config["equipment"].(map[string]interface{}); ok {
+		for k, v := range equipment {
+			if str, ok := v.(string); ok {
+				player.Equipment[k] = str
+			}
+		}
+	}
+
+	return player
+}
+
+// DisplayStatus はプレイヤーの現在の状態をgs.IO経由で表示する
+func (gs *GameState) DisplayStatus() {
+	var b strings.Builder
+	fmt.Fprintln(&b, "--- ステータス ---")
+	for stat, value := range gs.Player.Stats {
+		fmt.Fprintf(&b, "%s: %d\n", stat, value)
+	}
+	for attr, active := range gs.Player.Attributes {
+		if active {
+			fmt.Fprintf(&b, "Attribute: %s\n", attr)
+		}
+	}
+	fmt.Fprintln(&b, "Inventory:", gs.Player.Inventory)
+	fmt.Fprintln(&b, "Equipment:", gs.Player.Equipment)
+	if len(gs.Player.Effects) > 0 {
+		fmt.Fprintln(&b, "Effects:")
+		for _, effect := range gs.Player.Effects {
+			fmt.Fprintf(&b, "  %s (残り%dラウンド)\n", effect.Name, effect.Duration)
+		}
+	}
+	fmt.Fprint(&b, "--- ステータス ---")
+
+	gs.IO.ShowText(b.String())
+}
@@ -0,0 +1,37 @@
+package game
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// WeightedTable は重み付きの抽選テーブル。累積重みの配列を構築時に1度だけ
+// 計算しておき、Rollのたびにr.Intn(total)と二分探索を1回行うだけで済む。
+type WeightedTable struct {
+	entries    []TableEntry
+	cumulative []int
+	total      int
+}
+
+// NewWeightedTable はTableEntryのスライス（Node.Tableなど）からWeightedTableを構築する
+func NewWeightedTable(entries []TableEntry) *WeightedTable {
+	cumulative := make([]int, len(entries))
+	total := 0
+	for i, entry := range entries {
+		total += entry.Weight
+		cumulative[i] = total
+	}
+	return &WeightedTable{entries: entries, cumulative: cumulative, total: total}
+}
+
+// Roll はrから[0,total)の乱数を1つ引き、対応するエントリのNextNodeIDを返す。
+// エントリが無い場合は空文字列を返す。
+func (t *WeightedTable) Roll(r *rand.Rand) string {
+	if t.total <= 0 {
+		return ""
+	}
+
+	n := r.Intn(t.total)
+	idx := sort.Search(len(t.cumulative), func(i int) bool { return t.cumulative[i] > n })
+	return t.entries[idx].NextNodeID
+}
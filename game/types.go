@@ -5,6 +5,7 @@ import "bufio"
 // GameConfig はゲーム全体のTOML設定を表す
 type GameConfig struct {
 	System string                 `toml:"system"`
+	Seed   int64                  `toml:"seed,omitempty"`
 	Player map[string]interface{} `toml:"player"`
 	Nodes  []Node                 `toml:"nodes"`
 }
@@ -17,6 +18,20 @@ type Node struct {
 	Choices  []Choice  `toml:"choices,omitempty"`
 	Enemies  []*Enemy  `toml:"enemies,omitempty"`
 	Outcomes []Outcome `toml:"outcomes,omitempty"`
+
+	// Table はtype "random_encounter"のノードが使う重み付き抽選テーブル。
+	// 例: [[nodes.table]] weight = 75, next_node_id = "nothing"
+	Table []TableEntry `toml:"table,omitempty"`
+
+	// CombatResolver はこのノードの戦闘に使うCombatResolverを選択する
+	// ("crt", "d20", "formula" など)。空ならシステムのデフォルトを使う。
+	CombatResolver string `toml:"combat_resolver,omitempty"`
+}
+
+// TableEntry はWeightedTableの1エントリ。重みが大きいほど選ばれやすい。
+type TableEntry struct {
+	Weight     int    `toml:"weight"`
+	NextNodeID string `toml:"next_node_id"`
 }
 
 // Choice は選択肢を表す
@@ -31,6 +46,16 @@ type Enemy struct {
 	Name string `toml:"Name"`
 	HP   int    `toml:"HP"`
 	CS   int    `toml:"CS"`
+
+	// AC はd20系のCombatResolverで使う防御値。未設定(0)ならCSから見積もる。
+	AC int `toml:"AC,omitempty"`
+
+	// HPMax はDoT/HoTのダメージ/回復量の基準となる最大HP。未設定(0)なら
+	// NewEnemyActorが戦闘開始時点のHPを最大値として記録する。
+	HPMax int `toml:"HPMax,omitempty"`
+
+	// Effects はこの敵に現在かかっている継続効果
+	Effects []Effect `toml:"-" json:"effects,omitempty"`
 }
 
 // Outcome は遭遇戦の結果と次に進むノードを表す
@@ -39,6 +64,18 @@ type Outcome struct {
 	Condition    string `toml:"condition,omitempty"`
 	ConditionInt []int  `toml:"condition_int,omitempty"`
 	NextNodeID   string `toml:"next_node_id"`
+
+	// Apply はこのOutcomeが選ばれたときにプレイヤーへ付与する継続効果。
+	// 例: Apply = ["poison:3", "stun:1"]
+	Apply []string `toml:"apply,omitempty"`
+
+	// Table はこのOutcomeが選ばれたときの戦利品抽選テーブル（random_encounterの
+	// Node.Tableと同じWeightedTableで重み付き抽選する）。TableEntry.NextNodeID
+	// はノードIDではなく、抽選結果として所持品に追加するアイテム名として使う。
+	// 空文字列ならハズレとして何も追加しない。
+	// 例: [[nodes.outcomes.table]] weight = 90 next_node_id = ""
+	//     weight = 10 next_node_id = "gold_gem"
+	Table []TableEntry `toml:"table,omitempty"`
 }
 
 // Player はプレイヤーの状態を表す
@@ -47,6 +84,27 @@ type Player struct {
 	Attributes map[string]bool
 	Inventory  []string
 	Equipment  map[string]string
+
+	// Effects はプレイヤーに現在かかっている継続効果
+	Effects []Effect
+}
+
+// GameSystem はゲームブックごとのルール実装が満たすインターフェース
+//
+// 各システム（lonewolf, fightingfantasyなど）はこれを実装し、
+// ノードの処理方法や戦闘・アクションの解決方法を提供する。
+type GameSystem interface {
+	Initialize(config *GameConfig) error
+	HandleNode(gs *GameState, node Node) error
+	UpdatePlayer(gs *GameState, action string) error
+
+	// Serialize はシステム固有の内部状態（CRTの乱数列やシステム専用フラグなど）を
+	// バイト列に変換する。Player/CurrentNodeID/SeedはGameState側で保存されるため、
+	// ここにはそれ以外のシステム固有状態のみを含めればよい。
+	Serialize(gs *GameState) ([]byte, error)
+
+	// Deserialize はSerializeで得たバイト列からシステム固有の内部状態を復元する。
+	Deserialize(gs *GameState, data []byte) error
 }
 
 // GameState はゲームの状態を保持
@@ -55,4 +113,14 @@ type GameState struct {
 	CurrentNodeID string
 	Nodes         map[string]Node
 	Reader        *bufio.Reader
+	Config        *GameConfig
+	System        GameSystem
+
+	// IO はプレイヤーとの入出力を担う実装（TerminalIO, EbitenIOなど）。
+	// nilのままHandleNode等を呼ぶとパニックするため、必ずNewGameState等で設定すること。
+	IO IO
+
+	// Seed はシステムの乱数生成器を初期化するための種。
+	// セーブデータに含めることでリプレイを決定的にする。
+	Seed int64
 }
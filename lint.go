@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/BurntSushi/toml"
+
+	"gamebook/game"
+	"gamebook/game/graph"
+)
+
+// runLint は `gamebook lint` サブコマンドを実行する。TOML設定ファイルを
+// game/graphで解析し、到達不能ノード、ダングリングなnext_node_id参照、
+// アイテム/Kai能力なしでは抜け出せない循環、combat_wonを持たないencounter
+// ノード、出る辺を持たないデッドエンドノードを報告する。問題が見つかった
+// 場合は終了コード1を返す。
+func runLint(args []string) {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	configPath := fs.String("config", "game.toml", "検査するゲーム設定TOMLファイルのパス")
+	fs.Parse(args)
+
+	tomlData, err := os.ReadFile(*configPath)
+	if err != nil {
+		log.Fatalf("Error reading TOML file: %v", err)
+	}
+
+	var config game.GameConfig
+	if _, err := toml.Decode(string(tomlData), &config); err != nil {
+		log.Fatalf("Error decoding TOML: %v", err)
+	}
+
+	g := graph.Build(&config)
+	problems := 0
+
+	if len(config.Nodes) > 0 {
+		reachable := g.Reachable(config.Nodes[0].ID)
+		for _, node := range config.Nodes {
+			if !reachable[node.ID] {
+				fmt.Printf("unreachable node: %s\n", node.ID)
+				problems++
+			}
+		}
+	}
+
+	for _, node := range config.Nodes {
+		for _, choice := range node.Choices {
+			if choice.NextNodeID != "" && !g.HasNode(choice.NextNodeID) {
+				fmt.Printf("dangling next_node_id: %s -> %s\n", node.ID, choice.NextNodeID)
+				problems++
+			}
+		}
+		for _, outcome := range node.Outcomes {
+			if outcome.NextNodeID != "" && !g.HasNode(outcome.NextNodeID) {
+				fmt.Printf("dangling next_node_id: %s -> %s\n", node.ID, outcome.NextNodeID)
+				problems++
+			}
+		}
+	}
+
+	for _, cycle := range g.TrappingCycles() {
+		fmt.Printf("item-trapping cycle: %v\n", cycle)
+		problems++
+	}
+
+	for _, id := range g.DeadEnds() {
+		fmt.Printf("dead end (no outgoing edges): %s\n", id)
+		problems++
+	}
+
+	for _, node := range config.Nodes {
+		if node.Type != "encounter" {
+			continue
+		}
+
+		hasCombatWon := false
+		for _, outcome := range node.Outcomes {
+			if outcome.Condition == "combat_won" {
+				hasCombatWon = true
+				break
+			}
+		}
+		if !hasCombatWon {
+			fmt.Printf("encounter without combat_won outcome: %s\n", node.ID)
+			problems++
+		}
+	}
+
+	if problems == 0 {
+		fmt.Println("lint: no problems found")
+		return
+	}
+
+	fmt.Printf("lint: %d problem(s) found\n", problems)
+	os.Exit(1)
+}
@@ -0,0 +1,40 @@
+package lonewolf
+
+import "gamebook/game"
+
+// CRTResolver はLone Wolf伝統のCombat Results Table（CRT）による戦闘解決。
+// 1回のロールでattacker/defender双方のダメージが同時に決まるため、
+// game.CombatResolverのMutualはtrueを返す。
+type CRTResolver struct {
+	CRT map[KeyPair]DamagePair
+}
+
+func (r *CRTResolver) Name() string { return "crt" }
+func (r *CRTResolver) Mutual() bool { return true }
+
+func (r *CRTResolver) Resolve(attacker, defender game.CombatActor, ctx game.CombatContext) game.DamageResult {
+	combatRatio := normalizeCombatRatio(attacker.Attack() - defender.Attack())
+	randomNumber := ctx.Roll(10)
+
+	result, ok := r.CRT[KeyPair{RandNum: randomNumber, ComRatio: combatRatio}]
+	if !ok {
+		return game.DamageResult{}
+	}
+	return game.DamageResult{
+		DamageToDefender: result.EnemyLoss,
+		DamageToAttacker: result.PlayerLoss,
+	}
+}
+
+// resolverFor はNode.CombatResolverで指定された戦闘システムを選ぶ。
+// 未指定ならLone Wolf伝統のCRTを使う。
+func (lw *LoneWolfSystem) resolverFor(resolverName string) game.CombatResolver {
+	switch resolverName {
+	case "d20":
+		return &game.D20Resolver{DamageDie: 8}
+	case "formula":
+		return &game.FormulaResolver{AttackerRatio: 1.0, CritChance: 0.1, EvasionChance: 0.05}
+	default:
+		return &CRTResolver{CRT: lw.CRT}
+	}
+}
@@ -0,0 +1,291 @@
+// Package lonewolf はLone Wolfゲームブックシリーズのルール（CRTによる戦闘解決、
+// Kai能力や所持品による選択肢判定など）を実装するgame.GameSystemを提供する。
+package lonewolf
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+
+	"gamebook/game"
+)
+
+// KeyPair は戦闘結果テーブルのキーを定義
+type KeyPair struct {
+	RandNum  int `toml:"RandNum"`
+	ComRatio int `toml:"ComRatio"`
+}
+
+// DamagePair は戦闘結果テーブルの値を定義
+type DamagePair struct {
+	EnemyLoss  int  `toml:"EnemyLoss"`
+	PlayerLoss int  `toml:"PlayerLoss"`
+	IsKilled   bool `toml:"IsKilled"`
+}
+
+// CRTData はTOMLファイル全体の構造を定義
+type CRTData struct {
+	Results []struct {
+		KeyPair
+		DamagePair
+	} `toml:"results"`
+}
+
+// LoneWolfSystem はLone Wolfゲームブックのルールを実装
+type LoneWolfSystem struct {
+	CRT     map[KeyPair]DamagePair   // 戦闘結果テーブル
+	Roller  game.DeterministicRoller // 乱数生成器。GameConfig.Seedから決定的に供給する
+	CRTFile string                   // 戦闘結果テーブルのファイルパス
+}
+
+// NewLoneWolfSystem は新しいLoneWolfSystemインスタンスを生成
+func NewLoneWolfSystem(crtFile string) *LoneWolfSystem {
+	return &LoneWolfSystem{
+		CRT:     make(map[KeyPair]DamagePair),
+		CRTFile: crtFile,
+	}
+}
+
+// lonewolfState はSerialize/Deserializeでやり取りするシステム固有の状態。
+// 乱数自体はgs.SeedとCounterから決定的に再構築されるため、保存が必要なのは
+// Rollerがこれまでに何回ロールを引いたか（Counter）のみ。
+type lonewolfState struct {
+	RollCounter int64 `json:"roll_counter"`
+}
+
+// Initialize はLoneWolfSystemを初期化する。Rollerはconfig.Seedから
+// 決定的に構築されるため、同じSeedとセーブデータがあればリプレイが再現できる。
+func (lw *LoneWolfSystem) Initialize(config *game.GameConfig) error {
+	var data CRTData
+	if _, err := toml.DecodeFile(lw.CRTFile, &data); err != nil {
+		return fmt.Errorf("error decoding CRT file: %v", err)
+	}
+
+	for _, result := range data.Results {
+		lw.CRT[result.KeyPair] = result.DamagePair
+	}
+
+	lw.Roller = game.DeterministicRoller{Seed: config.Seed}
+	return nil
+}
+
+// HandleNode はノードタイプに応じて処理
+func (lw *LoneWolfSystem) HandleNode(gs *game.GameState, node game.Node) error {
+	switch node.Type {
+	case "story":
+		return lw.handleStoryNode(gs, node)
+	case "encounter":
+		return lw.handleEncounterNode(gs, node)
+	case "random_roll":
+		return lw.handleRandomNode(gs, node)
+	case "random_encounter":
+		return lw.handleRandomEncounterNode(gs, node)
+	case "end":
+		return nil
+	default:
+		return fmt.Errorf("unknown node type: %s", node.Type)
+	}
+}
+
+// UpdatePlayer はRunループから渡される heal/eat_meal などのアクションを処理する
+func (lw *LoneWolfSystem) UpdatePlayer(gs *game.GameState, action string) error {
+	switch action {
+	case "heal":
+		gs.Player.Stats["HP"] += 2
+		gs.IO.ShowText("休息して体力を2回復した。")
+	case "eat_meal":
+		if gs.Player.Stats["MEAL"] <= 0 {
+			return fmt.Errorf("食料を持っていない")
+		}
+		gs.Player.Stats["MEAL"]--
+		gs.Player.Stats["HP"] += 1
+		gs.IO.ShowText("食事をとって体力を1回復した。")
+	default:
+		return fmt.Errorf("unknown action: %s", action)
+	}
+	return nil
+}
+
+// Serialize はRollerの消費回数を保存する
+func (lw *LoneWolfSystem) Serialize(gs *game.GameState) ([]byte, error) {
+	return json.Marshal(lonewolfState{RollCounter: lw.Roller.Counter})
+}
+
+// Deserialize はRollerを保存時点のCounterから再開する
+func (lw *LoneWolfSystem) Deserialize(gs *game.GameState, data []byte) error {
+	var state lonewolfState
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &state); err != nil {
+			return fmt.Errorf("decoding lonewolf state: %v", err)
+		}
+	}
+
+	lw.Roller = game.DeterministicRoller{Seed: gs.Seed, Counter: state.RollCounter}
+	return nil
+}
+
+// handleStoryNode はストーリーノードを処理
+func (lw *LoneWolfSystem) handleStoryNode(gs *game.GameState, node game.Node) error {
+	if len(node.Choices) == 0 {
+		gs.CurrentNodeID = "game_over"
+		return fmt.Errorf("no choices available")
+	}
+
+	options := make([]string, len(node.Choices))
+	for i, choice := range node.Choices {
+		options[i] = choice.Description
+	}
+
+	for {
+		choice := node.Choices[gs.IO.AskChoice(options)]
+		if !lw.choiceAvailable(gs, choice) {
+			gs.IO.ShowText("その選択肢を選ぶための条件を満たしていません。")
+			gs.DisplayStatus()
+			continue
+		}
+
+		gs.CurrentNodeID = choice.NextNodeID
+		break
+	}
+	return nil
+}
+
+// choiceAvailable はChoice.Conditionsに書かれたKai能力/所持品の条件を満たしているか判定する
+func (lw *LoneWolfSystem) choiceAvailable(gs *game.GameState, choice game.Choice) bool {
+	for kind, value := range choice.Conditions {
+		switch kind {
+		case "discipline":
+			if !gs.Player.Attributes[value] {
+				return false
+			}
+		case "item":
+			if !containsStr(gs.Player.Inventory, value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// handleEncounterNode は戦闘ノードを処理
+func (lw *LoneWolfSystem) handleEncounterNode(gs *game.GameState, node game.Node) error {
+	gs.IO.ShowText("\n--- エンカウント！ ---")
+	resolver := lw.resolverFor(node.CombatResolver)
+	ctx := game.CombatContext{Roll: lw.Roller.Roll}
+	player := game.NewPlayerActor("Lone Wolf", gs.Player, "HP", "CS", "CS")
+
+	for _, enemyNode := range node.Enemies {
+		enemy := game.NewEnemyActor(enemyNode)
+		for {
+			playerHPBefore := player.HP()
+			enemyHPBefore := enemy.HP()
+
+			_, effectMsgs := game.RunEncounterRound(resolver, player, enemy, ctx)
+			for _, msg := range effectMsgs {
+				gs.IO.ShowText(msg)
+			}
+
+			gs.IO.ShowCombatRound(game.CombatRoundResult{
+				AttackerName:     "Lone Wolf",
+				DefenderName:     enemyNode.Name,
+				DamageToDefender: enemyHPBefore - enemy.HP(),
+				DamageToAttacker: playerHPBefore - player.HP(),
+				AttackerHP:       player.HP(),
+				AttackerHPMax:    player.HPMax(),
+				DefenderHP:       enemy.HP(),
+				DefenderHPMax:    enemy.HPMax(),
+			})
+
+			if enemy.HP() <= 0 {
+				gs.IO.ShowText(fmt.Sprintf("%sを倒した！", enemyNode.Name))
+				break
+			}
+			if player.HP() <= 0 {
+				gs.CurrentNodeID = "game_over"
+				return fmt.Errorf("player defeated")
+			}
+		}
+	}
+
+	for _, outcome := range node.Outcomes {
+		if outcome.Condition == "combat_won" {
+			if err := gs.ApplyOutcome(outcome, lw.Roller.Rand()); err != nil {
+				return err
+			}
+			gs.CurrentNodeID = outcome.NextNodeID
+			return nil
+		}
+	}
+	gs.CurrentNodeID = "game_over"
+	return fmt.Errorf("no combat_won outcome found")
+}
+
+// handleRandomNode はランダムノードを処理
+func (lw *LoneWolfSystem) handleRandomNode(gs *game.GameState, node game.Node) error {
+	randomNumber := lw.Roller.Roll(10)
+	gs.IO.ShowText(fmt.Sprintf("RandomNumberは%dです", randomNumber))
+
+	options := make([]string, len(node.Outcomes))
+	for i, outcome := range node.Outcomes {
+		options[i] = outcome.Description
+	}
+
+	for {
+		outcome := node.Outcomes[gs.IO.AskChoice(options)]
+		if containsInt(outcome.ConditionInt, randomNumber) {
+			if err := gs.ApplyOutcome(outcome, lw.Roller.Rand()); err != nil {
+				return err
+			}
+			gs.CurrentNodeID = outcome.NextNodeID
+			break
+		} else {
+			gs.IO.ShowText("条件を満たしていません。")
+			gs.DisplayStatus()
+		}
+	}
+	return nil
+}
+
+// handleRandomEncounterNode はNode.Tableによる重み付き抽選でランダムエンカウントを処理する
+func (lw *LoneWolfSystem) handleRandomEncounterNode(gs *game.GameState, node game.Node) error {
+	table := game.NewWeightedTable(node.Table)
+	next := table.Roll(lw.Roller.Rand())
+	if next == "" {
+		return fmt.Errorf("random_encounter table is empty: %s", node.ID)
+	}
+
+	gs.CurrentNodeID = next
+	return nil
+}
+
+// normalizeCombatRatio は戦闘比率を正規化
+func normalizeCombatRatio(ratio int) int {
+	if ratio <= -11 {
+		return -11
+	}
+	if ratio >= 11 {
+		return 11
+	}
+	return ratio
+}
+
+// containsStr はスライスに指定された文字列が含まれるか確認
+func containsStr(slice []string, str string) bool {
+	for _, s := range slice {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+// containsInt はスライスに指定された整数が含まれるか確認
+func containsInt(slice []int, number int) bool {
+	for _, i := range slice {
+		if i == number {
+			return true
+		}
+	}
+	return false
+}
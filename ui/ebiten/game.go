@@ -0,0 +1,297 @@
+//go:build ebitenui
+
+// Package ebiten はgame.IOのEbiten実装を提供する。ゲームロジック(Run())は
+// イベントループを持たない単純な手続きのまま別goroutineで動かし、表示内容は
+// muで保護した共有フィールド経由でGameへ渡し、選択肢への回答はチャネル経由で
+// 受け取る。実際の
+// ウィンドウ描画とキー/マウスイベントの処理はebiten.RunGameが呼ぶ
+// Update/Drawが担う、というブリッジ構成になっている。
+//
+// ebitenはcgo経由でX11/OpenGLに依存するため、このパッケージはビルドタグ
+// ebitenuiを指定したとき（go build -tags ebitenui）のみビルドされる。
+// 既定のビルド（gamebook lintやterminal UI）はこのパッケージに触れず、
+// GUI開発ヘッダの無いサーバー/CI環境でもビルドできる。
+package ebiten
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	"gamebook/game"
+)
+
+const (
+	screenWidth  = 640
+	screenHeight = 480
+
+	lineHeight  = 16
+	optionStart = 300 // 選択肢が始まるY座標
+	barWidth    = 200
+	barHeight   = 12
+
+	// hpAnimSpeed は1フレームあたりにHPバーが追いつく割合。値が小さいほど
+	// ダメージ/回復がゆっくりバーに反映される。
+	hpAnimSpeed = 0.15
+)
+
+var textFace = basicfont.Face7x13
+
+// hpGauge はHPバー1本分の表示状態。displayHPは毎フレームhpへ指数的に近づく
+// ことでアニメーションさせる。
+type hpGauge struct {
+	name      string
+	hp        int
+	hpMax     int
+	displayHP float64
+}
+
+// choiceBox は選択肢1つ分のクリック可能領域
+type choiceBox struct {
+	rect image.Rectangle
+}
+
+// Game はebiten.Gameを実装する。フィールドはEbitenIOとの間でmuで保護しつつ共有する。
+type Game struct {
+	mu sync.Mutex
+
+	text    string
+	options []string
+	boxes   []choiceBox
+
+	attacker *hpGauge
+	defender *hpGauge
+
+	// pending は現在回答待ちの選択肢があればそのチャネルを保持する。
+	// nilなら回答待ちではない(Updateはキー入力/クリックを無視する)。
+	pending chan int
+}
+
+// NewGame は新しいGameインスタンスを生成
+func NewGame() *Game {
+	return &Game{}
+}
+
+// Update はキー入力とマウスクリックを処理する。1〜9の数字キー、または
+// 選択肢の表示領域のクリックで応答できる。
+func (g *Game) Update() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.animateHP(g.attacker)
+	g.animateHP(g.defender)
+
+	if g.pending == nil {
+		return nil
+	}
+
+	if choice, ok := g.pickByKey(); ok {
+		g.answer(choice)
+		return nil
+	}
+	if choice, ok := g.pickByClick(); ok {
+		g.answer(choice)
+	}
+	return nil
+}
+
+// animateHP はgaugeの表示HPを実HPへ指数的に近づける
+func (g *Game) animateHP(gauge *hpGauge) {
+	if gauge == nil {
+		return
+	}
+	diff := float64(gauge.hp) - gauge.displayHP
+	if diff > -0.5 && diff < 0.5 {
+		gauge.displayHP = float64(gauge.hp)
+		return
+	}
+	gauge.displayHP += diff * hpAnimSpeed
+}
+
+// pickByKey は1〜9キーが押されていれば対応するインデックスを返す
+func (g *Game) pickByKey() (int, bool) {
+	for i := range g.options {
+		if i >= 9 {
+			break
+		}
+		key := ebiten.Key(int(ebiten.Key1) + i)
+		if inpututil.IsKeyJustPressed(key) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// pickByClick はクリック位置が選択肢の表示領域に含まれていればそのインデックスを返す
+func (g *Game) pickByClick() (int, bool) {
+	if !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return 0, false
+	}
+	x, y := ebiten.CursorPosition()
+	for i, box := range g.boxes {
+		if (image.Point{X: x, Y: y}).In(box.rect) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// answer はpendingチャネルに選ばれたインデックスを送り、回答待ち状態を解除する
+func (g *Game) answer(choice int) {
+	ch := g.pending
+	g.pending = nil
+	ch <- choice
+}
+
+// Draw はノード本文・選択肢・戦闘パネル(HPバー)を1画面にまとめて表示する。
+func (g *Game) Draw(screen *ebiten.Image) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	screen.Fill(color.RGBA{R: 20, G: 20, B: 30, A: 255})
+
+	drawText(screen, g.text, 16, 24)
+
+	g.boxes = make([]choiceBox, len(g.options))
+	for i, opt := range g.options {
+		label := fmt.Sprintf("[%d] %s", i+1, opt)
+		y := optionStart + i*lineHeight
+		drawText(screen, label, 16, y)
+		g.boxes[i] = choiceBox{rect: image.Rect(16, y-lineHeight+4, 16+textWidth(label), y+4)}
+	}
+
+	drawHPGauge(screen, g.attacker, 16, screenHeight-56)
+	drawHPGauge(screen, g.defender, screenWidth-barWidth-16, screenHeight-56)
+}
+
+// drawText はfaceを使ってテキスト(複数行可)を(x, y)を起点に描画する
+func drawText(screen *ebiten.Image, text string, x, y int) {
+	d := &font.Drawer{
+		Dst:  screen,
+		Src:  image.NewUniform(color.White),
+		Face: textFace,
+	}
+	for i, line := range strings.Split(text, "\n") {
+		d.Dot = fixed.P(x, y+i*lineHeight)
+		d.DrawString(line)
+	}
+}
+
+// textWidth はtextFaceでlineを描画したときの幅(px)を返す
+func textWidth(line string) int {
+	return font.MeasureString(textFace, line).Ceil()
+}
+
+// drawHPGauge はgaugeのHPバーと"name HP:x/y"のラベルを(x, y)に描画する。
+// gaugeがnilの場合は何も描画しない。
+func drawHPGauge(screen *ebiten.Image, gauge *hpGauge, x, y int) {
+	if gauge == nil {
+		return
+	}
+
+	drawText(screen, fmt.Sprintf("%s HP:%d/%d", gauge.name, gauge.hp, gauge.hpMax), x, y)
+
+	barY := y + 6
+	bg := image.Rect(x, barY, x+barWidth, barY+barHeight)
+	screen.SubImage(bg).(*ebiten.Image).Fill(color.RGBA{R: 60, G: 10, B: 10, A: 255})
+
+	ratio := 0.0
+	if gauge.hpMax > 0 {
+		ratio = gauge.displayHP / float64(gauge.hpMax)
+	}
+	if ratio < 0 {
+		ratio = 0
+	}
+	filled := image.Rect(x, barY, x+int(float64(barWidth)*ratio), barY+barHeight)
+	screen.SubImage(filled).(*ebiten.Image).Fill(color.RGBA{R: 200, G: 30, B: 30, A: 255})
+}
+
+// Layout はウィンドウの論理解像度を返す
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
+
+// EbitenIO はGameを介してプレイヤーとやり取りするgame.IO実装
+type EbitenIO struct {
+	game *Game
+}
+
+// NewEbitenIO は新しいEbitenIOインスタンスを生成
+func NewEbitenIO(g *Game) *EbitenIO {
+	return &EbitenIO{game: g}
+}
+
+// ShowText は画面の本文を差し替える
+func (io *EbitenIO) ShowText(text string) {
+	io.game.mu.Lock()
+	io.game.text = text
+	io.game.options = nil
+	io.game.mu.Unlock()
+}
+
+// AskChoice は選択肢を画面に表示し、数字キーまたはクリックで応答されるまでブロックする
+func (io *EbitenIO) AskChoice(options []string) int {
+	ch := make(chan int)
+
+	io.game.mu.Lock()
+	io.game.options = options
+	io.game.pending = ch
+	io.game.mu.Unlock()
+
+	return <-ch
+}
+
+// ReadLine はRunループのアクション入力(heal/eat_meal/save/load/skip)を
+// 固定メニューとして提示し、選ばれた文字列を返す。
+func (io *EbitenIO) ReadLine(prompt string) string {
+	actions := []string{"heal", "eat_meal", "save", "load", "skip"}
+	io.ShowText(prompt)
+	return actions[io.AskChoice(actions)]
+}
+
+// ShowCombatRound は戦闘の両者のHPバーを画面下部のパネルに反映する。
+// バーの増減はUpdateで毎フレーム少しずつ追いつくようにアニメーションする。
+func (io *EbitenIO) ShowCombatRound(result game.CombatRoundResult) {
+	io.game.mu.Lock()
+	defer io.game.mu.Unlock()
+
+	io.game.attacker = updateGauge(io.game.attacker, result.AttackerName, result.AttackerHP, result.AttackerHPMax)
+	io.game.defender = updateGauge(io.game.defender, result.DefenderName, result.DefenderHP, result.DefenderHPMax)
+}
+
+// updateGauge はgaugeをresultの値で更新する。gaugeがまだ無い(このラウンドが
+// 最初)場合は、displayHPを現在のHPに一致させることでバーがいきなり満タン
+// から減るような見た目にならないようにする。
+func updateGauge(gauge *hpGauge, name string, hp, hpMax int) *hpGauge {
+	if gauge == nil {
+		return &hpGauge{name: name, hp: hp, hpMax: hpMax, displayHP: float64(hp)}
+	}
+	gauge.name = name
+	gauge.hp = hp
+	gauge.hpMax = hpMax
+	return gauge
+}
+
+// Run はgsのIOをEbitenIOに差し替え、loop(通常はゲームのメインループ関数)を
+// 別goroutineで走らせながら、ebitenのウィンドウを開いてイベントループを開始する。
+// ebiten.RunGameはウィンドウが閉じるまでブロックするため、呼び出し元のgoroutine
+// (mainなど)で呼ぶこと。
+func Run(gs *game.GameState, loop func()) error {
+	g := NewGame()
+	gs.IO = NewEbitenIO(g)
+
+	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowTitle("Gamebook")
+
+	go loop()
+
+	return ebiten.RunGame(g)
+}